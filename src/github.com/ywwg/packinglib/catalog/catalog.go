@@ -0,0 +1,107 @@
+// Package catalog loads packinglib.Item definitions from a data file instead
+// of constructing them in Go, so end users can add or tweak items without
+// recompiling. The on-disk format is TOML, modelled on the one-section-per-kind
+// style used by data-driven game configs: [[basic]], [[temperature]],
+// [[consumable]], [[consumable_temperature]], and [[custom]]. A generic
+// [[item]] section with a "kind" field reaches any other kind registered
+// via packinglib.RegisterItemKind, including ones from third-party plugins
+// this package has never heard of.
+package catalog
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ywwg/packinglib"
+)
+
+// builtinSections lists the catalog sections every kind of Item Load knows
+// about by name, matching a registered packinglib.ItemKind of the same
+// name.
+var builtinSections = []string{"basic", "temperature", "consumable", "consumable_temperature", "custom"}
+
+// genericSection is the catalog section for item kinds that aren't one of
+// builtinSections -- e.g. a kind a third-party plugin registered. Each
+// entry's "kind" field selects the packinglib.ItemKind factory to dispatch
+// to, with the rest of the entry passed through as that factory's spec.
+const genericSection = "item"
+
+// Load reads a TOML catalog from path and returns the items it describes,
+// in file order within each of builtinSections. c is used only to validate
+// that every allow/disallow property referenced in the file is one the
+// context knows about; pass nil to skip validation.
+func Load(path string, c *packinglib.Context) ([]packinglib.Item, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: reading %s: %w", path, err)
+	}
+	sections, err := parseTOML(data)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: parsing %s: %w", path, err)
+	}
+	return build(sections, c)
+}
+
+func build(sections map[string][]map[string]interface{}, c *packinglib.Context) ([]packinglib.Item, error) {
+	var items []packinglib.Item
+
+	for _, kind := range builtinSections {
+		for _, spec := range sections[kind] {
+			item, err := makeItem(kind, c, spec)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+	}
+
+	for _, spec := range sections[genericSection] {
+		kind := packinglib.SpecString(spec, "kind")
+		if kind == "" {
+			return nil, fmt.Errorf("catalog: [[%s]] entry missing required %q field", genericSection, "kind")
+		}
+		item, err := makeItem(kind, c, spec)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// makeItem validates spec's allow/disallow properties against c and then
+// builds the item through whatever factory is registered for kind -- the
+// same packinglib.RegisterItemKind registry third-party plugins use. The
+// built-in kinds are registered by packinglib itself, and RegisterItemKind
+// panics on a duplicate name, so a plugin cannot replace them.
+func makeItem(kind string, c *packinglib.Context, spec map[string]interface{}) (packinglib.Item, error) {
+	name := packinglib.SpecString(spec, "name")
+	if err := validate(c, name, packinglib.SpecStringSlice(spec, "allow"), packinglib.SpecStringSlice(spec, "disallow")); err != nil {
+		return nil, err
+	}
+	factory, ok := packinglib.ItemKind(kind)
+	if !ok {
+		return nil, fmt.Errorf("catalog: item %q: no item kind registered for %q", name, kind)
+	}
+	item, err := factory(spec)
+	if err != nil {
+		return nil, fmt.Errorf("catalog: item %q: %w", name, err)
+	}
+	return item, nil
+}
+
+// validate checks that every allow/disallow property referenced by an item
+// is a property the context actually tracks, so a typo in a catalog file
+// fails at load time instead of silently never matching.
+func validate(c *packinglib.Context, name string, allow, disallow []string) error {
+	if c == nil {
+		return nil
+	}
+	for _, p := range append(append([]string{}, allow...), disallow...) {
+		if _, ok := c.Properties[packinglib.Property(p)]; !ok {
+			return fmt.Errorf("catalog: item %q references unknown property %q", name, p)
+		}
+	}
+	return nil
+}
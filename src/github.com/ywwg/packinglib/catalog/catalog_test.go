@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"testing"
+
+	"github.com/ywwg/packinglib"
+	"github.com/ywwg/packinglib/examples/weighteditem"
+	"github.com/ywwg/packinglib/expr"
+)
+
+// handCoded returns the same items testdata/catalog.toml describes, built
+// the way a user would without the catalog package.
+func handCoded() []packinglib.Item {
+	return []packinglib.Item{
+		packinglib.NewBasicItem("tent", []string{"car_camping"}, nil),
+		packinglib.NewTemperatureItem("down jacket", -20, 40, nil, nil),
+		packinglib.NewConsumableItem("coffee", 1.5, "cup", nil, nil),
+		packinglib.NewConsumableTemperatureItem("sunscreen", 0.5, "oz", 60, 110, nil, nil),
+		packinglib.NewCustomConsumableItem("trash bags", expr.Compile("ceil(days/3)+1"), packinglib.NoUnits, nil, nil),
+	}
+}
+
+func TestLoadRoundTrip(t *testing.T) {
+	c := packinglib.NewContext([]string{"car_camping"}, nil, 30, 50)
+
+	loaded, err := Load("testdata/catalog.toml", c)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	hand := handCoded()
+	if len(loaded) != len(hand) {
+		t.Fatalf("Load returned %d items, hand-coded catalog has %d", len(loaded), len(hand))
+	}
+
+	trip := packinglib.NewTrip(c, 3, nil)
+
+	for i := range hand {
+		gotPacked := loaded[i].Pack(trip)
+		wantPacked := hand[i].Pack(trip)
+		if got, want := gotPacked.String(), wantPacked.String(); got != want {
+			t.Errorf("item %d: Load().Pack().String() = %q, want %q (hand-coded)", i, got, want)
+		}
+	}
+}
+
+// TestLoadGenericItemSection confirms the generic [[item]] section reaches
+// a kind the catalog package itself knows nothing about -- here,
+// weighteditem's "weighted", registered via packinglib.RegisterItemKind by
+// a plugin's own init().
+func TestLoadGenericItemSection(t *testing.T) {
+	c := packinglib.NewContext(nil, nil, 30, 50)
+
+	loaded, err := Load("testdata/catalog_plugin.toml", c)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("Load returned %d items, want 2", len(loaded))
+	}
+
+	stove, ok := loaded[1].(*weighteditem.WeightedItem)
+	if !ok {
+		t.Fatalf("loaded[1] is %T, want *weighteditem.WeightedItem", loaded[1])
+	}
+	if stove.Name != "camp stove" || stove.WeightGrams != 450 {
+		t.Errorf("stove = %+v, want name %q weight 450", stove, "camp stove")
+	}
+}
+
+func TestLoadValidatesProperties(t *testing.T) {
+	// tent's "car_camping" allow property isn't a property this context
+	// tracks at all, so Load should fail fast instead of silently never
+	// matching.
+	c := packinglib.NewContext(nil, nil, 30, 50)
+	c.Properties = packinglib.PropertySet{}
+
+	if _, err := Load("testdata/catalog.toml", c); err == nil {
+		t.Fatal("Load: expected an error for an unknown property, got nil")
+	}
+}
@@ -0,0 +1,90 @@
+package catalog
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// parseTOML parses the small subset of TOML catalogs actually use: a
+// sequence of array-of-tables headers ([[kind]]) each followed by
+// "key = value" assignments, where value is a quoted string, a bare
+// number, or a single-line array of quoted strings. Comments start with #.
+//
+// It is deliberately not a general-purpose TOML parser -- just enough to
+// read catalog files without pulling in a third-party dependency for a
+// handful of scalar and array fields.
+func parseTOML(data []byte) (map[string][]map[string]interface{}, error) {
+	sections := map[string][]map[string]interface{}{}
+	var current map[string]interface{}
+	var currentName string
+
+	for lineNo, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[[") && strings.HasSuffix(line, "]]") {
+			if current != nil {
+				sections[currentName] = append(sections[currentName], current)
+			}
+			currentName = strings.TrimSpace(line[2 : len(line)-2])
+			current = map[string]interface{}{}
+			continue
+		}
+
+		if current == nil {
+			return nil, fmt.Errorf("line %d: key outside of any [[section]]: %q", lineNo+1, line)
+		}
+
+		key, rawVal, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value: %q", lineNo+1, line)
+		}
+		val, err := parseTOMLValue(strings.TrimSpace(rawVal))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNo+1, err)
+		}
+		current[strings.TrimSpace(key)] = val
+	}
+	if current != nil {
+		sections[currentName] = append(sections[currentName], current)
+	}
+	return sections, nil
+}
+
+func parseTOMLValue(s string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		if len(s) < 2 || !strings.HasSuffix(s, `"`) {
+			return nil, fmt.Errorf("unterminated string: %q", s)
+		}
+		return s[1 : len(s)-1], nil
+
+	case strings.HasPrefix(s, "["):
+		if !strings.HasSuffix(s, "]") {
+			return nil, fmt.Errorf("unterminated array: %q", s)
+		}
+		inner := strings.TrimSpace(s[1 : len(s)-1])
+		if inner == "" {
+			return []interface{}{}, nil
+		}
+		var out []interface{}
+		for _, part := range strings.Split(inner, ",") {
+			v, err := parseTOMLValue(strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+
+	default:
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("unrecognized value: %q", s)
+		}
+		return f, nil
+	}
+}
@@ -0,0 +1,51 @@
+package packinglib
+
+// Property is the name of a single fact about a trip's context, e.g.
+// "winter" or "car_camping". Items gate on whether a Context has a given
+// property, and whether it is allowed or disallowed.
+type Property string
+
+// PropertySet is the set of properties an item's Prerequisites reference.
+// The stored bool records whether the property came from an item's allow
+// list (true) or disallow list (false); Satisfies only inspects the
+// property names, so most callers can treat this as a plain set.
+type PropertySet map[Property]bool
+
+// buildPropertySet merges an item's allow and disallow property names into
+// a single PropertySet, as stored on BasicItem.Prerequisites.
+func buildPropertySet(allow, disallow []string) PropertySet {
+	ps := PropertySet{}
+	for _, p := range allow {
+		ps[Property(p)] = true
+	}
+	for _, p := range disallow {
+		ps[Property(p)] = false
+	}
+	return ps
+}
+
+// Context describes the trip being packed for: which properties hold (e.g.
+// "winter": true, "car_camping": false) and the anticipated temperature
+// range, which TemperatureItem gates on directly.
+type Context struct {
+	// Properties records which properties hold for this trip. An item
+	// with Prerequisites only appears if at least one of them is present
+	// here and set to true, and none of them is present and set to false.
+	Properties PropertySet
+
+	// TemperatureMin is the anticipated minimum temperature.
+	TemperatureMin int
+
+	// TemperatureMax is the anticipated maximum temperature.
+	TemperatureMax int
+}
+
+// NewContext builds a Context from allow/disallow-style property lists (set
+// true/false respectively) and a temperature range.
+func NewContext(allow, disallow []string, temperatureMin, temperatureMax int) *Context {
+	return &Context{
+		Properties:     buildPropertySet(allow, disallow),
+		TemperatureMin: temperatureMin,
+		TemperatureMax: temperatureMax,
+	}
+}
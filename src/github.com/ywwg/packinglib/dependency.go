@@ -0,0 +1,198 @@
+package packinglib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Named is implemented by any item that can be referred to by name from
+// another item's Requires/Unlocks list. BasicItem (and everything that
+// embeds it once) implements this automatically; ConsumableTemperatureItem,
+// which embeds it twice, implements it through explicit overrides.
+type Named interface {
+	Item
+
+	// ItemName returns the name used in other items' Requires/Unlocks lists.
+	ItemName() string
+}
+
+// DependentItem is a Named item that itself declares dependencies on other
+// items having been packed. BasicItem implements this with a possibly-empty
+// Requirements slice, so ordinary items satisfy the interface trivially.
+type DependentItem interface {
+	Named
+
+	// Requirements returns the items (and minimum packed counts) this item
+	// requires before it is eligible to pack.
+	Requirements() []ItemRequirement
+}
+
+// unlockingItem is a Named item that declares an Unlocks list. BasicItem
+// implements this too.
+type unlockingItem interface {
+	Named
+
+	// ItemUnlocks returns the items that only become eligible once this
+	// item is packed.
+	ItemUnlocks() []string
+}
+
+// zeroable lets the resolver force an already-packed item's count back to
+// zero when its Requires are never met, without needing to know its
+// concrete type. BasicItem (and everything that embeds it) implements this.
+type zeroable interface {
+	resetCount()
+}
+
+// ResolveDependencies packs items against t, honoring item-to-item
+// dependency edges declared via Requires and its inverse, Unlocks (so a
+// tech tree can be authored from either end): an item with unmet
+// requirements is skipped until the items it depends on have packed at the
+// required count, repeating in passes until no further item becomes
+// eligible (a fixed point). An item whose requirements are never met is
+// still packed -- so its type and Satisfies-driven state are intact -- but
+// has its count forced to zero, so Requires behaves as an additional gate
+// layered on top of Satisfies rather than a hard failure.
+//
+// It returns packed items in the same order as items, or an error if the
+// dependency graph described by Requires/Unlocks contains a cycle.
+func ResolveDependencies(items []Item, t *Trip) ([]Item, error) {
+	implied := impliedRequirements(items)
+
+	if err := checkDependencyCycles(items, implied); err != nil {
+		return nil, err
+	}
+
+	packed := make([]Item, len(items))
+	done := make([]bool, len(items))
+	counts := map[string]float64{}
+
+	for {
+		progressed := false
+		for idx, it := range items {
+			if done[idx] {
+				continue
+			}
+			if !requirementsMet(effectiveRequirements(it, implied), counts) {
+				continue
+			}
+			p := it.Pack(t)
+			packed[idx] = p
+			done[idx] = true
+			progressed = true
+			if n, ok := it.(Named); ok {
+				counts[n.ItemName()] += p.Count()
+			}
+		}
+		if !progressed {
+			break
+		}
+	}
+
+	for idx, it := range items {
+		if done[idx] {
+			continue
+		}
+		p := it.Pack(t)
+		if z, ok := p.(zeroable); ok {
+			z.resetCount()
+		}
+		packed[idx] = p
+	}
+
+	return packed, nil
+}
+
+// impliedRequirements turns every item's Unlocks list into the equivalent
+// Requires edge on the unlocked item: if "tent" unlocks "rainfly", then
+// "rainfly" implicitly requires "tent" even if rainfly.Requires doesn't say
+// so itself.
+func impliedRequirements(items []Item) map[string][]ItemRequirement {
+	implied := map[string][]ItemRequirement{}
+	for _, it := range items {
+		u, ok := it.(unlockingItem)
+		if !ok {
+			continue
+		}
+		for _, name := range u.ItemUnlocks() {
+			implied[name] = append(implied[name], ItemRequirement{Name: u.ItemName()})
+		}
+	}
+	return implied
+}
+
+// effectiveRequirements merges an item's own declared Requires with any
+// requirements implied by other items' Unlocks.
+func effectiveRequirements(it Item, implied map[string][]ItemRequirement) []ItemRequirement {
+	dep, ok := it.(DependentItem)
+	if !ok {
+		return nil
+	}
+	reqs := append([]ItemRequirement{}, dep.Requirements()...)
+	return append(reqs, implied[dep.ItemName()]...)
+}
+
+func requirementsMet(reqs []ItemRequirement, counts map[string]float64) bool {
+	for _, req := range reqs {
+		threshold := req.MinCount
+		if threshold <= 0 {
+			threshold = 1
+		}
+		if counts[req.Name] < threshold {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDependencyCycles walks the Requires/Unlocks graph with a standard
+// white/gray/black DFS and returns an error describing the cycle if one is
+// found.
+func checkDependencyCycles(items []Item, implied map[string][]ItemRequirement) error {
+	requires := map[string][]string{}
+	for _, it := range items {
+		dep, ok := it.(DependentItem)
+		if !ok {
+			continue
+		}
+		name := dep.ItemName()
+		for _, req := range effectiveRequirements(it, implied) {
+			requires[name] = append(requires[name], req.Name)
+		}
+	}
+
+	const (
+		white = iota
+		gray
+		black
+	)
+	color := map[string]int{}
+	var path []string
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch color[name] {
+		case black:
+			return nil
+		case gray:
+			return fmt.Errorf("packinglib: dependency cycle detected: %s -> %s", strings.Join(path, " -> "), name)
+		}
+		color[name] = gray
+		path = append(path, name)
+		for _, req := range requires[name] {
+			if err := visit(req); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		color[name] = black
+		return nil
+	}
+
+	for name := range requires {
+		if err := visit(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
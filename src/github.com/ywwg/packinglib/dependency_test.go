@@ -0,0 +1,141 @@
+package packinglib
+
+import "testing"
+
+func countOf(t *testing.T, items []Item, name string) float64 {
+	t.Helper()
+	for _, it := range items {
+		if n, ok := it.(Named); ok && n.ItemName() == name {
+			return it.Count()
+		}
+	}
+	t.Fatalf("item %q not found in packed list", name)
+	return 0
+}
+
+func TestResolveDependenciesDiamond(t *testing.T) {
+	base := NewBasicItem("base", nil, nil)
+	left := NewBasicItemWithDependencies("left", nil, nil, []ItemRequirement{{Name: "base"}}, nil)
+	right := NewBasicItemWithDependencies("right", nil, nil, []ItemRequirement{{Name: "base"}}, nil)
+	top := NewBasicItemWithDependencies("top", nil, nil, []ItemRequirement{{Name: "left"}, {Name: "right"}}, nil)
+
+	trip := NewTrip(NewContext(nil, nil, 0, 0), 1, []Item{top, left, right, base})
+
+	packed, err := ResolveDependencies(trip.Items, trip)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed, "top"); got != 1 {
+		t.Errorf("top.Count() = %v, want 1", got)
+	}
+}
+
+func TestResolveDependenciesUnmetRequirementIsNotPacked(t *testing.T) {
+	// tent is deliberately never included, so stakes' requirement can
+	// never be met.
+	stakes := NewBasicItemWithDependencies("stakes", nil, nil, []ItemRequirement{{Name: "tent"}}, nil)
+
+	trip := NewTrip(NewContext(nil, nil, 0, 0), 1, []Item{stakes})
+
+	packed, err := ResolveDependencies(trip.Items, trip)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed, "stakes"); got != 0 {
+		t.Errorf("stakes.Count() = %v, want 0 (requirement never met)", got)
+	}
+}
+
+func TestResolveDependenciesQuantityThreshold(t *testing.T) {
+	stove := NewConsumableItem("stove", 1, NoUnits, nil, nil)
+	fuel := NewBasicItemWithDependencies("fuel", nil, nil, []ItemRequirement{{Name: "stove", MinCount: 1}}, nil)
+
+	trip := NewTrip(NewContext(nil, nil, 0, 0), 3, []Item{fuel, stove})
+
+	packed, err := ResolveDependencies(trip.Items, trip)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed, "fuel"); got != 1 {
+		t.Errorf("fuel.Count() = %v, want 1 (stove packed at count >= 1)", got)
+	}
+}
+
+func TestResolveDependenciesUnlocksIsInverseOfRequires(t *testing.T) {
+	tent := NewBasicItem("tent", nil, nil)
+	tent.Unlocks = []string{"rainfly"}
+	rainfly := NewBasicItem("rainfly", nil, nil)
+
+	trip := NewTrip(NewContext(nil, nil, 0, 0), 1, []Item{rainfly, tent})
+
+	packed, err := ResolveDependencies(trip.Items, trip)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed, "rainfly"); got != 1 {
+		t.Errorf("rainfly.Count() = %v, want 1 (unlocked by tent)", got)
+	}
+}
+
+func TestResolveDependenciesCycle(t *testing.T) {
+	a := NewBasicItemWithDependencies("a", nil, nil, []ItemRequirement{{Name: "b"}}, nil)
+	b := NewBasicItemWithDependencies("b", nil, nil, []ItemRequirement{{Name: "a"}}, nil)
+
+	trip := NewTrip(NewContext(nil, nil, 0, 0), 1, []Item{a, b})
+
+	if _, err := ResolveDependencies(trip.Items, trip); err == nil {
+		t.Fatal("ResolveDependencies: expected a cycle error, got nil")
+	}
+}
+
+func TestResolveDependenciesConsumableTemperatureItem(t *testing.T) {
+	// sunscreen is a ConsumableTemperatureItem on both ends of a
+	// dependency edge: it requires "tent" to have packed, and its own
+	// Unlocks makes "aloe" eligible. Both only work if ConsumableTemperatureItem
+	// satisfies Named/DependentItem/unlockingItem despite embedding
+	// BasicItem twice (via ConsumableItem and TemperatureItem).
+	tent := NewBasicItem("tent", nil, nil)
+	sunscreen := NewConsumableTemperatureItem("sunscreen", 0.5, NoUnits, 60, 110, nil, nil)
+	sunscreen.ConsumableItem.BasicItem.Requires = []ItemRequirement{{Name: "tent"}}
+	sunscreen.ConsumableItem.BasicItem.Unlocks = []string{"aloe"}
+	aloe := NewBasicItem("aloe", nil, nil)
+
+	trip := NewTrip(NewContext(nil, nil, 70, 90), 2, []Item{sunscreen, aloe, tent})
+
+	packed, err := ResolveDependencies(trip.Items, trip)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed, "sunscreen"); got != 1 {
+		t.Errorf("sunscreen.Count() = %v, want 1 (tent packed)", got)
+	}
+	if got := countOf(t, packed, "aloe"); got != 1 {
+		t.Errorf("aloe.Count() = %v, want 1 (unlocked by sunscreen)", got)
+	}
+}
+
+func TestResolveDependenciesTemperatureGating(t *testing.T) {
+	tent := NewBasicItem("tent", nil, nil)
+	rainfly := NewTemperatureItem("rainfly", -20, 40, nil, nil)
+	rainfly.Requires = []ItemRequirement{{Name: "tent"}}
+
+	cold := NewContext(nil, nil, -10, 10)
+	trip := NewTrip(cold, 1, []Item{rainfly, tent})
+	packed, err := ResolveDependencies(trip.Items, trip)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed, "rainfly"); got != 1 {
+		t.Errorf("rainfly.Count() in range = %v, want 1", got)
+	}
+
+	warm := NewContext(nil, nil, 50, 60)
+	trip2 := NewTrip(warm, 1, []Item{rainfly, tent})
+	packed2, err := ResolveDependencies(trip2.Items, trip2)
+	if err != nil {
+		t.Fatalf("ResolveDependencies: %v", err)
+	}
+	if got := countOf(t, packed2, "rainfly"); got != 0 {
+		t.Errorf("rainfly.Count() out of range = %v, want 0 (temperature gate, independent of Requires)", got)
+	}
+}
@@ -0,0 +1,69 @@
+// Package weighteditem is an example packinglib plugin. It adds a
+// "weighted" item kind that behaves like a BasicItem but also carries a
+// weight in grams, and registers a Decorator that totals and prints the
+// weight of everything packed. It demonstrates both extension points added
+// for third-party item types; downstream users can follow the same shape
+// in their own module. Import it for its side effects:
+//
+//	import _ "github.com/ywwg/packinglib/examples/weighteditem"
+package weighteditem
+
+import (
+	"fmt"
+
+	"github.com/ywwg/packinglib"
+)
+
+// WeightedItem is a BasicItem that also reports how much it weighs, in
+// grams, once packed.
+type WeightedItem struct {
+	packinglib.BasicItem
+
+	// WeightGrams is the weight of a single packed unit, in grams.
+	WeightGrams float64
+}
+
+// NewWeightedItem builds a WeightedItem with the given per-unit weight.
+func NewWeightedItem(name string, weightGrams float64, allow, disallow []string) *WeightedItem {
+	return &WeightedItem{
+		BasicItem:   *packinglib.NewBasicItem(name, allow, disallow),
+		WeightGrams: weightGrams,
+	}
+}
+
+func (i *WeightedItem) Pack(t *packinglib.Trip) packinglib.Item {
+	p := &WeightedItem{}
+	*p = *i
+	p.BasicItem = *i.BasicItem.Pack(t).(*packinglib.BasicItem)
+	return p
+}
+
+// TotalWeightGrams returns the weight contributed by this item's packed
+// count.
+func (i *WeightedItem) TotalWeightGrams() float64 {
+	return i.WeightGrams * i.Count()
+}
+
+func init() {
+	packinglib.RegisterItemKind("weighted", func(spec map[string]interface{}) (packinglib.Item, error) {
+		name := packinglib.SpecString(spec, "name")
+		if name == "" {
+			return nil, fmt.Errorf("weighteditem: spec missing required \"name\" field")
+		}
+		return NewWeightedItem(name, packinglib.SpecFloat(spec, "weight_grams"),
+			packinglib.SpecStringSlice(spec, "allow"), packinglib.SpecStringSlice(spec, "disallow")), nil
+	})
+
+	packinglib.RegisterDecorator(func(t *packinglib.Trip, packed []packinglib.Item) []packinglib.Item {
+		var total float64
+		for _, it := range packed {
+			if w, ok := it.(*WeightedItem); ok {
+				total += w.TotalWeightGrams()
+			}
+		}
+		if total > 0 {
+			fmt.Printf("total pack weight: %.0fg\n", total)
+		}
+		return packed
+	})
+}
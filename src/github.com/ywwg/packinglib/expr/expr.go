@@ -0,0 +1,199 @@
+// Package expr evaluates the small arithmetic expressions used by custom
+// rate formulas in item catalogs, e.g. "ceil(days/3)+1".
+//
+// It supports +, -, *, /, parentheses, integer and float literals, a single
+// "days" variable, and the functions ceil, floor, and round. It is not a
+// general purpose expression language; it exists so catalog authors can
+// describe a rate without writing Go.
+package expr
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Eval parses and evaluates expression with "days" bound to days.
+func Eval(expression string, days int) (float64, error) {
+	p := &parser{input: []rune(expression), vars: map[string]float64{"days": float64(days)}}
+	v, err := p.parseAddSub()
+	if err != nil {
+		return 0, fmt.Errorf("expr: %q: %w", expression, err)
+	}
+	p.skipSpace()
+	if !p.atEnd() {
+		return 0, fmt.Errorf("expr: %q: unexpected trailing input at %d", expression, p.pos)
+	}
+	return v, nil
+}
+
+// Compile returns a func(days int) float64 suitable for use as a
+// CustomConsumableItem.RateFunc. Callers should validate expression with
+// Eval at load time; Compile itself swallows evaluation errors as 0 since
+// RateFunc has no error return.
+func Compile(expression string) func(days int) float64 {
+	return func(days int) float64 {
+		v, err := Eval(expression, days)
+		if err != nil {
+			return 0
+		}
+		return v
+	}
+}
+
+type parser struct {
+	input []rune
+	pos   int
+	vars  map[string]float64
+}
+
+func (p *parser) atEnd() bool { return p.pos >= len(p.input) }
+
+func (p *parser) peek() rune {
+	if p.atEnd() {
+		return 0
+	}
+	return p.input[p.pos]
+}
+
+func (p *parser) skipSpace() {
+	for !p.atEnd() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *parser) parseAddSub() (float64, error) {
+	left, err := p.parseMulDiv()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpace()
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseMulDiv()
+		if err != nil {
+			return 0, err
+		}
+		if op == '+' {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+}
+
+func (p *parser) parseMulDiv() (float64, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpace()
+		op := p.peek()
+		if op != '*' && op != '/' {
+			return left, nil
+		}
+		p.pos++
+		right, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		if op == '*' {
+			left *= right
+		} else {
+			if right == 0 {
+				return 0, fmt.Errorf("division by zero")
+			}
+			left /= right
+		}
+	}
+}
+
+func (p *parser) parseUnary() (float64, error) {
+	p.skipSpace()
+	if p.peek() == '-' {
+		p.pos++
+		v, err := p.parseUnary()
+		return -v, err
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (float64, error) {
+	p.skipSpace()
+	switch {
+	case p.peek() == '(':
+		p.pos++
+		v, err := p.parseAddSub()
+		if err != nil {
+			return 0, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("expected ')'")
+		}
+		p.pos++
+		return v, nil
+	case unicode.IsDigit(p.peek()) || p.peek() == '.':
+		return p.parseNumber()
+	case unicode.IsLetter(p.peek()):
+		return p.parseIdentOrCall()
+	default:
+		return 0, fmt.Errorf("unexpected character %q at %d", p.peek(), p.pos)
+	}
+}
+
+func (p *parser) parseNumber() (float64, error) {
+	start := p.pos
+	for !p.atEnd() && (unicode.IsDigit(p.peek()) || p.peek() == '.') {
+		p.pos++
+	}
+	s := string(p.input[start:p.pos])
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("bad number %q", s)
+	}
+	return v, nil
+}
+
+func (p *parser) parseIdentOrCall() (float64, error) {
+	start := p.pos
+	for !p.atEnd() && (unicode.IsLetter(p.peek()) || unicode.IsDigit(p.peek())) {
+		p.pos++
+	}
+	name := string(p.input[start:p.pos])
+	p.skipSpace()
+	if p.peek() == '(' {
+		p.pos++
+		arg, err := p.parseAddSub()
+		if err != nil {
+			return 0, err
+		}
+		p.skipSpace()
+		if p.peek() != ')' {
+			return 0, fmt.Errorf("expected ')' after %s(", name)
+		}
+		p.pos++
+		switch strings.ToLower(name) {
+		case "ceil":
+			return math.Ceil(arg), nil
+		case "floor":
+			return math.Floor(arg), nil
+		case "round":
+			return math.Round(arg), nil
+		default:
+			return 0, fmt.Errorf("unknown function %q", name)
+		}
+	}
+	v, ok := p.vars[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown variable %q", name)
+	}
+	return v, nil
+}
@@ -21,6 +21,18 @@ type Item interface {
 	String() string
 }
 
+// ItemRequirement is one entry in a BasicItem's Requires list: the named
+// item must be packed at a count of at least MinCount before this item is
+// eligible to pack. MinCount of zero means "must be packed at all", i.e. a
+// threshold of 1.
+type ItemRequirement struct {
+	// Name is the ItemName of the required item.
+	Name string
+
+	// MinCount is the minimum Count() the required item must reach.
+	MinCount float64
+}
+
 // BasicItem is the simplest item -- just prerequisites and no count, like "tent"
 type BasicItem struct {
 	// Name of the item.
@@ -31,6 +43,16 @@ type BasicItem struct {
 
 	// Prerequisites is a set of all properties that the context must have for this item to appear.
 	Prerequisites PropertySet
+
+	// Requires lists other items that must already be packed, at an
+	// optional minimum count, before this item can pack itself. e.g. tent
+	// stakes require "tent" to have packed.
+	Requires []ItemRequirement
+
+	// Unlocks names the items that only become eligible once this item
+	// packs. It is the inverse of those items' Requires, present so a
+	// tech-tree style catalog can be authored from either end.
+	Unlocks []string
 }
 
 func NewBasicItem(name string, allow, disallow []string) *BasicItem {
@@ -40,6 +62,41 @@ func NewBasicItem(name string, allow, disallow []string) *BasicItem {
 	}
 }
 
+// NewBasicItemWithDependencies is NewBasicItem plus the item-to-item
+// Requires/Unlocks edges consumed by ResolveDependencies.
+func NewBasicItemWithDependencies(name string, allow, disallow []string, requires []ItemRequirement, unlocks []string) *BasicItem {
+	i := NewBasicItem(name, allow, disallow)
+	i.Requires = requires
+	i.Unlocks = unlocks
+	return i
+}
+
+// ItemName returns the name other items' Requires/Unlocks entries use to
+// refer to this item. Unlike String, it is stable regardless of count or
+// units formatting.
+func (i *BasicItem) ItemName() string {
+	return i.Name
+}
+
+// Requirements returns the other items (and minimum packed counts) this
+// item depends on, satisfying DependentItem.
+func (i *BasicItem) Requirements() []ItemRequirement {
+	return i.Requires
+}
+
+// ItemUnlocks returns the items that only become eligible to pack once this
+// item packs, satisfying the resolver's unlockingItem interface.
+func (i *BasicItem) ItemUnlocks() []string {
+	return i.Unlocks
+}
+
+// resetCount forces the packed count back to zero. ResolveDependencies uses
+// this to gate an item whose Requires were never met, after Pack has
+// already run its own Satisfies-based packing.
+func (i *BasicItem) resetCount() {
+	i.count = 0
+}
+
 // Satisfies returns true if the context satisfies the item's requirements.
 func (i *BasicItem) Satisfies(c *Context) bool {
 	// Any property satisfies (OR)
@@ -139,11 +196,24 @@ func (i *ConsumableItem) Pack(t *Trip) Item {
 	p := &ConsumableItem{}
 	*p = *i
 	if p.Satisfies(t.C) {
-		p.count = math.Ceil(i.DailyRate * float64(t.Days))
+		p.count = math.Ceil(consumableTotal(i.DailyRate, t))
 	}
 	return p
 }
 
+// consumableTotal sums a flat daily rate over every day of the trip: each
+// date in t.Schedule if one is set, otherwise t.Days() undated days.
+func consumableTotal(rate float64, t *Trip) float64 {
+	if t.Schedule != nil {
+		var total float64
+		for range t.Schedule.Dates() {
+			total += rate
+		}
+		return total
+	}
+	return rate * float64(t.Days())
+}
+
 func (i *ConsumableItem) String() string {
 	if i.Units == NoUnits {
 		if i.count == float64(int(i.count)) {
@@ -178,7 +248,7 @@ func (i *CustomConsumableItem) Pack(t *Trip) Item {
 	p := &CustomConsumableItem{}
 	*p = *i
 	if p.Satisfies(t.C) {
-		p.count = i.RateFunc(t.Days)
+		p.count = i.RateFunc(t.Days())
 	}
 	return p
 }
@@ -207,7 +277,7 @@ func (i *ConsumableTemperatureItem) Pack(t *Trip) Item {
 	p := &ConsumableTemperatureItem{}
 	*p = *i
 	if p.Satisfies(t.C) {
-		p.ConsumableItem.count = math.Ceil(i.DailyRate * float64(t.Days))
+		p.ConsumableItem.count = math.Ceil(consumableTotal(i.DailyRate, t))
 	}
 	return p
 }
@@ -219,3 +289,28 @@ func (i *ConsumableTemperatureItem) Count() float64 {
 func (i *ConsumableTemperatureItem) String() string {
 	return i.ConsumableItem.String()
 }
+
+// resetCount overrides the ambiguous promotion of BasicItem.resetCount
+// through the two embedded BasicItems (via ConsumableItem and
+// TemperatureItem), mirroring Count()'s explicit override above.
+func (i *ConsumableTemperatureItem) resetCount() {
+	i.ConsumableItem.count = 0
+}
+
+// ItemName, Requirements, and ItemUnlocks override the same ambiguous
+// promotion for the Named/DependentItem/unlockingItem interfaces the
+// dependency resolver uses: without these, a ConsumableTemperatureItem
+// can't be referred to by name, can't declare Requires, and can't declare
+// Unlocks, since Go refuses to promote a method present at equal depth in
+// both embedded BasicItems.
+func (i *ConsumableTemperatureItem) ItemName() string {
+	return i.ConsumableItem.BasicItem.ItemName()
+}
+
+func (i *ConsumableTemperatureItem) Requirements() []ItemRequirement {
+	return i.ConsumableItem.BasicItem.Requirements()
+}
+
+func (i *ConsumableTemperatureItem) ItemUnlocks() []string {
+	return i.ConsumableItem.BasicItem.ItemUnlocks()
+}
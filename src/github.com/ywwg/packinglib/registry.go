@@ -0,0 +1,173 @@
+package packinglib
+
+import (
+	"fmt"
+
+	"github.com/ywwg/packinglib/expr"
+)
+
+// ItemFactory builds an Item from a kind-specific spec. spec uses the same
+// map[string]interface{} shape a TOML/YAML/JSON decoder produces for a
+// table, e.g. {"name": "tent", "allow": []interface{}{"car_camping"}}.
+type ItemFactory func(spec map[string]interface{}) (Item, error)
+
+// Decorator wraps the items a Trip has packed -- to total weight, group by
+// category, emit JSON, and so on -- without subclassing every concrete
+// item. It returns the (possibly modified, reordered, or re-sliced) list
+// that should be used in its place.
+type Decorator func(t *Trip, packed []Item) []Item
+
+var (
+	itemKinds  = map[string]ItemFactory{}
+	decorators []Decorator
+)
+
+// RegisterItemKind makes name available as a packable item kind: the
+// catalog loader dispatches a [[name]] table (for the built-in kinds) or
+// an [[item]] table with kind = name to factory, and any package
+// that imports packinglib can call RegisterItemKind from its own init() to
+// add a new kind without modifying packinglib itself. It panics on a
+// duplicate name or nil factory, the same convention database/sql.Register
+// uses.
+func RegisterItemKind(name string, factory ItemFactory) {
+	if factory == nil {
+		panic("packinglib: RegisterItemKind: nil factory for kind " + name)
+	}
+	if _, dup := itemKinds[name]; dup {
+		panic("packinglib: RegisterItemKind: kind " + name + " already registered")
+	}
+	itemKinds[name] = factory
+}
+
+// ItemKind returns the factory registered for name, if any.
+func ItemKind(name string) (ItemFactory, bool) {
+	f, ok := itemKinds[name]
+	return f, ok
+}
+
+// RegisteredItemKinds returns the names of all registered item kinds, for
+// diagnostics and catalog validation.
+func RegisteredItemKinds() []string {
+	names := make([]string, 0, len(itemKinds))
+	for name := range itemKinds {
+		names = append(names, name)
+	}
+	return names
+}
+
+// RegisterDecorator adds d to the chain of decorators RunDecorators applies,
+// in registration order.
+func RegisterDecorator(d Decorator) {
+	decorators = append(decorators, d)
+}
+
+// RunDecorators applies every registered decorator to packed, in
+// registration order, and returns the result. Trip.Pack calls this once it
+// has finished resolving items, so plugins can post-process the final pack
+// list.
+func RunDecorators(t *Trip, packed []Item) []Item {
+	for _, d := range decorators {
+		packed = d(t, packed)
+	}
+	return packed
+}
+
+// SpecString returns spec[key] as a string, or "" if it is absent or not a
+// string.
+func SpecString(spec map[string]interface{}, key string) string {
+	s, _ := spec[key].(string)
+	return s
+}
+
+// SpecFloat returns spec[key] as a float64, accepting any numeric type a
+// TOML/YAML/JSON decoder might produce. Missing or non-numeric values yield
+// 0.
+func SpecFloat(spec map[string]interface{}, key string) float64 {
+	switch v := spec[key].(type) {
+	case float64:
+		return v
+	case int:
+		return float64(v)
+	case int64:
+		return float64(v)
+	default:
+		return 0
+	}
+}
+
+// SpecInt is SpecFloat truncated to an int.
+func SpecInt(spec map[string]interface{}, key string) int {
+	return int(SpecFloat(spec, key))
+}
+
+// SpecStringSlice returns spec[key] as a []string, or nil if it is absent
+// or not a slice of strings.
+func SpecStringSlice(spec map[string]interface{}, key string) []string {
+	raw, ok := spec[key].([]interface{})
+	if !ok {
+		return nil
+	}
+	out := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// basicSpecFields pulls the fields every built-in kind shares off of spec.
+func basicSpecFields(spec map[string]interface{}) (name string, allow, disallow []string, err error) {
+	name = SpecString(spec, "name")
+	if name == "" {
+		return "", nil, nil, fmt.Errorf("packinglib: item spec missing required \"name\" field")
+	}
+	return name, SpecStringSlice(spec, "allow"), SpecStringSlice(spec, "disallow"), nil
+}
+
+func init() {
+	RegisterItemKind("basic", func(spec map[string]interface{}) (Item, error) {
+		name, allow, disallow, err := basicSpecFields(spec)
+		if err != nil {
+			return nil, err
+		}
+		return NewBasicItem(name, allow, disallow), nil
+	})
+
+	RegisterItemKind("temperature", func(spec map[string]interface{}) (Item, error) {
+		name, allow, disallow, err := basicSpecFields(spec)
+		if err != nil {
+			return nil, err
+		}
+		return NewTemperatureItem(name, SpecInt(spec, "temperature_min"), SpecInt(spec, "temperature_max"), allow, disallow), nil
+	})
+
+	RegisterItemKind("consumable", func(spec map[string]interface{}) (Item, error) {
+		name, allow, disallow, err := basicSpecFields(spec)
+		if err != nil {
+			return nil, err
+		}
+		return NewConsumableItem(name, SpecFloat(spec, "rate"), SpecString(spec, "units"), allow, disallow), nil
+	})
+
+	RegisterItemKind("consumable_temperature", func(spec map[string]interface{}) (Item, error) {
+		name, allow, disallow, err := basicSpecFields(spec)
+		if err != nil {
+			return nil, err
+		}
+		return NewConsumableTemperatureItem(name, SpecFloat(spec, "rate"), SpecString(spec, "units"),
+			SpecInt(spec, "temperature_min"), SpecInt(spec, "temperature_max"), allow, disallow), nil
+	})
+
+	RegisterItemKind("custom", func(spec map[string]interface{}) (Item, error) {
+		name, allow, disallow, err := basicSpecFields(spec)
+		if err != nil {
+			return nil, err
+		}
+		rate := SpecString(spec, "rate")
+		if _, err := expr.Eval(rate, 1); err != nil {
+			return nil, err
+		}
+		return NewCustomConsumableItem(name, expr.Compile(rate), SpecString(spec, "units"), allow, disallow), nil
+	})
+}
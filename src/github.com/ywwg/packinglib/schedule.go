@@ -0,0 +1,194 @@
+package packinglib
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TripSchedule anchors a Trip to real calendar dates instead of a bare day
+// count, so items can reason about weekdays, specific dates, and
+// timezones.
+type TripSchedule struct {
+	// Start is the first day of the trip, inclusive.
+	Start time.Time
+
+	// End is the last day of the trip, inclusive.
+	End time.Time
+
+	// Location is the timezone Start/End/Dates are interpreted in.
+	// Defaults to time.Local if nil.
+	Location *time.Location
+}
+
+// TripScheduleConfig is the tag-driven shape a TripSchedule is parsed from,
+// e.g. loaded from YAML or environment variables:
+//
+//	start: 2026-08-01
+//	end: 2026-08-05
+//	tz: America/Los_Angeles
+type TripScheduleConfig struct {
+	Start string `yaml:"start" env:"TRIP_START"`
+	End   string `yaml:"end" env:"TRIP_END"`
+	TZ    string `yaml:"tz" env:"TRIP_TZ"`
+}
+
+const scheduleDateLayout = "2006-01-02"
+
+// LoadTripScheduleConfig builds a TripScheduleConfig from a minimal
+// "key: value" YAML file at path, then overlays any matching environment
+// variables -- the same tag-driven shape config libraries like
+// kelseyhightower/envconfig use, matching struct fields by their `yaml`/
+// `env` tags. An empty path skips the file and reads only the environment.
+func LoadTripScheduleConfig(path string) (TripScheduleConfig, error) {
+	var cfg TripScheduleConfig
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("packinglib: reading %s: %w", path, err)
+		}
+		if err := decodeFlatYAML(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("packinglib: parsing %s: %w", path, err)
+		}
+	}
+	applyEnv(&cfg)
+	return cfg, nil
+}
+
+// decodeFlatYAML parses a flat "key: value" document (no nesting, no
+// lists) into v's string fields, matched by their `yaml` struct tag. It is
+// not a general YAML parser -- just enough to load a TripScheduleConfig
+// without a third-party dependency.
+func decodeFlatYAML(data []byte, v interface{}) error {
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+
+	fieldByTag := map[string]int{}
+	for i := 0; i < rt.NumField(); i++ {
+		if tag := rt.Field(i).Tag.Get("yaml"); tag != "" {
+			fieldByTag[tag] = i
+		}
+	}
+
+	for lineNo, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, val, ok := strings.Cut(line, ":")
+		if !ok {
+			return fmt.Errorf("line %d: expected \"key: value\": %q", lineNo+1, line)
+		}
+		key = strings.TrimSpace(key)
+		idx, ok := fieldByTag[key]
+		if !ok {
+			return fmt.Errorf("line %d: unknown key %q", lineNo+1, key)
+		}
+		rv.Field(idx).SetString(strings.Trim(strings.TrimSpace(val), `"`))
+	}
+	return nil
+}
+
+// applyEnv overwrites v's string fields from the environment, matched by
+// their `env` struct tag, for any variable that is actually set.
+func applyEnv(v interface{}) {
+	rv := reflect.ValueOf(v).Elem()
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		tag := rt.Field(i).Tag.Get("env")
+		if tag == "" {
+			continue
+		}
+		if val, ok := os.LookupEnv(tag); ok {
+			rv.Field(i).SetString(val)
+		}
+	}
+}
+
+// ParseTripSchedule turns a TripScheduleConfig into a TripSchedule. TZ is
+// resolved with time.LoadLocation and defaults to the system zone when
+// empty; Start/End are parsed as calendar dates in that zone.
+func ParseTripSchedule(cfg TripScheduleConfig) (*TripSchedule, error) {
+	loc := time.Local
+	if cfg.TZ != "" {
+		l, err := time.LoadLocation(cfg.TZ)
+		if err != nil {
+			return nil, fmt.Errorf("packinglib: loading timezone %q: %w", cfg.TZ, err)
+		}
+		loc = l
+	}
+
+	start, err := time.ParseInLocation(scheduleDateLayout, cfg.Start, loc)
+	if err != nil {
+		return nil, fmt.Errorf("packinglib: parsing start date %q: %w", cfg.Start, err)
+	}
+	end, err := time.ParseInLocation(scheduleDateLayout, cfg.End, loc)
+	if err != nil {
+		return nil, fmt.Errorf("packinglib: parsing end date %q: %w", cfg.End, err)
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("packinglib: trip end %s is before start %s", cfg.End, cfg.Start)
+	}
+
+	return &TripSchedule{Start: start, End: end, Location: loc}, nil
+}
+
+// Days returns the number of days in the schedule, inclusive of both ends.
+func (s *TripSchedule) Days() int {
+	return len(s.Dates())
+}
+
+// Dates returns every calendar date from Start to End inclusive, in the
+// schedule's Location, at midnight.
+func (s *TripSchedule) Dates() []time.Time {
+	loc := s.Location
+	if loc == nil {
+		loc = time.Local
+	}
+	var dates []time.Time
+	last := dateOnly(s.End, loc)
+	for d := dateOnly(s.Start, loc); !d.After(last); d = d.AddDate(0, 0, 1) {
+		dates = append(dates, d)
+	}
+	return dates
+}
+
+func dateOnly(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+// ScheduledConsumableItem is a ConsumableItem whose rate varies by calendar
+// date instead of being a flat per-day average, e.g. "2 beers on Fri/Sat, 1
+// otherwise" or "extra coffee on the first day". Packing it requires the
+// Trip to have a Schedule set, since a bare day count carries no weekday or
+// date information.
+type ScheduledConsumableItem struct {
+	ConsumableItem
+
+	// RateFunc returns how much of the item is used on the given day.
+	RateFunc func(day time.Time) float64
+}
+
+func NewScheduledConsumableItem(name string, rateFunc func(day time.Time) float64, units string, allow, disallow []string) *ScheduledConsumableItem {
+	return &ScheduledConsumableItem{
+		ConsumableItem: *NewConsumableItem(name, 0, units, allow, disallow),
+		RateFunc:       rateFunc,
+	}
+}
+
+func (i *ScheduledConsumableItem) Pack(t *Trip) Item {
+	p := &ScheduledConsumableItem{}
+	*p = *i
+	if p.Satisfies(t.C) && t.Schedule != nil {
+		var total float64
+		for _, day := range t.Schedule.Dates() {
+			total += i.RateFunc(day)
+		}
+		p.count = math.Ceil(total)
+	}
+	return p
+}
@@ -0,0 +1,80 @@
+package packinglib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestParseTripSchedule(t *testing.T) {
+	cfg := TripScheduleConfig{Start: "2026-08-01", End: "2026-08-05", TZ: "America/Los_Angeles"}
+
+	s, err := ParseTripSchedule(cfg)
+	if err != nil {
+		t.Fatalf("ParseTripSchedule: %v", err)
+	}
+	if got, want := s.Days(), 5; got != want {
+		t.Errorf("Days() = %d, want %d", got, want)
+	}
+	if got, want := s.Location.String(), "America/Los_Angeles"; got != want {
+		t.Errorf("Location = %s, want %s", got, want)
+	}
+	if got, want := s.Dates()[0].Weekday(), time.Saturday; got != want {
+		t.Errorf("first date weekday = %s, want %s", got, want)
+	}
+}
+
+func TestParseTripScheduleRejectsEndBeforeStart(t *testing.T) {
+	cfg := TripScheduleConfig{Start: "2026-08-05", End: "2026-08-01"}
+	if _, err := ParseTripSchedule(cfg); err == nil {
+		t.Fatal("ParseTripSchedule: expected an error for end before start, got nil")
+	}
+}
+
+func TestScheduledConsumableItemPack(t *testing.T) {
+	s, err := ParseTripSchedule(TripScheduleConfig{Start: "2026-08-01", End: "2026-08-04", TZ: "UTC"})
+	if err != nil {
+		t.Fatalf("ParseTripSchedule: %v", err)
+	}
+
+	beer := NewScheduledConsumableItem("beer", func(day time.Time) float64 {
+		if day.Weekday() == time.Friday || day.Weekday() == time.Saturday {
+			return 2
+		}
+		return 1
+	}, NoUnits, nil, nil)
+
+	trip := NewScheduledTrip(NewContext(nil, nil, 0, 0), s, nil)
+	packed := beer.Pack(trip)
+
+	// 2026-08-01 is a Saturday, so the 4-day window is Sat/Sun/Mon/Tue: 2+1+1+1.
+	if got, want := packed.Count(), 5.0; got != want {
+		t.Errorf("beer.Count() = %v, want %v", got, want)
+	}
+}
+
+func TestLoadTripScheduleConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "schedule.yaml")
+	if err := os.WriteFile(path, []byte("start: 2026-08-01\nend: 2026-08-05\ntz: UTC\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	cfg, err := LoadTripScheduleConfig(path)
+	if err != nil {
+		t.Fatalf("LoadTripScheduleConfig: %v", err)
+	}
+	if cfg.Start != "2026-08-01" || cfg.End != "2026-08-05" || cfg.TZ != "UTC" {
+		t.Fatalf("LoadTripScheduleConfig = %+v, want start/end/tz from file", cfg)
+	}
+
+	t.Setenv("TRIP_TZ", "America/Los_Angeles")
+	cfg, err = LoadTripScheduleConfig(path)
+	if err != nil {
+		t.Fatalf("LoadTripScheduleConfig: %v", err)
+	}
+	if cfg.TZ != "America/Los_Angeles" {
+		t.Errorf("TRIP_TZ env var did not override file value: TZ = %q", cfg.TZ)
+	}
+}
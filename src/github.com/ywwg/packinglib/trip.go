@@ -0,0 +1,47 @@
+package packinglib
+
+// Trip is the thing being packed for: a Context plus the candidate Items to
+// consider, and either a flat day count or a calendar TripSchedule.
+type Trip struct {
+	// C is the context the trip packs against.
+	C *Context
+
+	// Schedule anchors the trip to real calendar dates. When set, Days()
+	// is derived from it instead of the flat count passed to NewTrip.
+	Schedule *TripSchedule
+
+	// Items is the full list of candidate items to pack.
+	Items []Item
+
+	days int
+}
+
+// NewTrip builds a Trip with a flat day count.
+func NewTrip(c *Context, days int, items []Item) *Trip {
+	return &Trip{C: c, days: days, Items: items}
+}
+
+// NewScheduledTrip builds a Trip whose Days() is derived from schedule.
+func NewScheduledTrip(c *Context, schedule *TripSchedule, items []Item) *Trip {
+	return &Trip{C: c, Schedule: schedule, Items: items}
+}
+
+// Days returns the number of days in the trip: schedule.Days() when a
+// Schedule is set, otherwise the flat count passed to NewTrip.
+func (t *Trip) Days() int {
+	if t.Schedule != nil {
+		return t.Schedule.Days()
+	}
+	return t.days
+}
+
+// Pack packs every item in t.Items against t: it resolves item-to-item
+// Requires/Unlocks dependencies to a fixed point via ResolveDependencies,
+// then runs every registered Decorator over the result.
+func (t *Trip) Pack() ([]Item, error) {
+	packed, err := ResolveDependencies(t.Items, t)
+	if err != nil {
+		return nil, err
+	}
+	return RunDecorators(t, packed), nil
+}